@@ -0,0 +1,43 @@
+package define
+
+import "time"
+
+// ProgressOption selects how build progress is reported.
+type ProgressOption string
+
+const (
+	// ProgressAuto picks plain or pretty text output based on whether
+	// output is a terminal, matching today's default behavior.
+	ProgressAuto ProgressOption = "auto"
+	// ProgressPlain always emits the existing human-readable text output.
+	ProgressPlain ProgressOption = "plain"
+	// ProgressJSON emits newline-delimited JSON progress events instead
+	// of human text, for CI systems and IDE integrations to consume.
+	ProgressJSON ProgressOption = "json"
+)
+
+// ProgressEvent is one newline-delimited JSON object emitted to a build's
+// ReportWriter when Progress is ProgressJSON.
+type ProgressEvent struct {
+	ID          string     `json:"id"`
+	Stage       string     `json:"stage"`
+	Instruction string     `json:"instruction,omitempty"`
+	Started     *time.Time `json:"started,omitempty"`
+	Completed   *time.Time `json:"completed,omitempty"`
+	ExitCode    *int       `json:"exitCode,omitempty"`
+	Digest      string     `json:"digest,omitempty"`
+	// Log events carry a chunk of stdout/stderr for the step named by ID
+	// instead of a start/finish transition.
+	Stream string `json:"stream,omitempty"`
+	Data   string `json:"data,omitempty"`
+}
+
+// ProgressWriter is called by the executor at instruction start/finish and
+// on each read from a running step's stdio, so that callers can render
+// progress however they like (text, JSON, a UI) without the executor
+// knowing which.
+type ProgressWriter interface {
+	StepStarted(event ProgressEvent)
+	StepFinished(event ProgressEvent)
+	Log(event ProgressEvent)
+}
@@ -0,0 +1,19 @@
+package define
+
+// SBOMScanOptions holds the parsed --sbom* flag values that control
+// generation of a software bill of materials for a build.
+type SBOMScanOptions struct {
+	// Scanner is the name of the scanner to run, e.g. "syft" or "trivy".
+	Scanner string
+	// Image is an optional scanner image to run instead of an in-tree
+	// scanner binary.
+	Image string
+	// Output is the host path the scan document should be written to.
+	Output string
+	// ImageOutput, if set, attaches the scan document to the committed
+	// image as an additional layer at this in-container path.
+	ImageOutput string
+	// ImagePURLOutput, if set, writes the package URL list to this host
+	// path in addition to the full scan document.
+	ImagePURLOutput string
+}
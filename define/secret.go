@@ -0,0 +1,16 @@
+package define
+
+// Secret represents a single build-time secret passed with --secret, to be
+// mounted read-only into a single RUN step via
+// `RUN --mount=type=secret,id=<ID>` and never committed into a layer.
+type Secret struct {
+	// ID is the identifier a Dockerfile's RUN --mount=type=secret,id=<ID>
+	// uses to refer to this secret. Required, and must be unique.
+	ID string
+	// Source is the path on the host to read the secret's contents from.
+	// Mutually exclusive with Env.
+	Source string
+	// Env is the name of a host environment variable to read the secret's
+	// contents from. Mutually exclusive with Source.
+	Env string
+}
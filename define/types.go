@@ -0,0 +1,229 @@
+package define
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+	encconfig "github.com/containers/ocicrypt/config"
+	"github.com/containers/storage/pkg/archive"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// Compression is reexported from containers/storage/pkg/archive so that
+// callers of this package don't need to import it directly.
+type Compression = archive.Compression
+
+const (
+	Uncompressed = archive.Uncompressed
+	Gzip         = archive.Gzip
+)
+
+// Format constants accepted by the --format flag.
+const (
+	OCI    = "oci"
+	DOCKER = "docker"
+)
+
+// Manifest/image media type constants used once a format has been resolved.
+const (
+	OCIv1ImageManifest    = "application/vnd.oci.image.manifest.v1+json"
+	Dockerv2ImageManifest = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// PullPolicy takes the value of IfMissing, Always, IfNewer, or Never.
+type PullPolicy int
+
+const (
+	PullIfMissing PullPolicy = iota
+	PullAlways
+	PullIfNewer
+	PullNever
+)
+
+// Isolation is the type of process isolation used when running a command
+// inside of a container.
+type Isolation int
+
+const (
+	IsolationDefault Isolation = iota
+	IsolationOCI
+	IsolationChroot
+	IsolationOCIRootless
+)
+
+// NetworkConfigurationPolicy takes the value of NetworkDefault, NetworkDisabled,
+// or NetworkEnabled.
+type NetworkConfigurationPolicy int
+
+const (
+	NetworkDefault NetworkConfigurationPolicy = iota
+	NetworkDisabled
+	NetworkEnabled
+)
+
+// NamespaceOption controls how a single namespace is configured.
+type NamespaceOption struct {
+	Name string
+	Host bool
+	Path string
+}
+
+// NamespaceOptions is a slice of NamespaceOption, in the order the user asked
+// for them to be applied.
+type NamespaceOptions []NamespaceOption
+
+// AddOrReplace either adds or replaces the configuration for a given
+// namespace, matched by name.
+func (n *NamespaceOptions) AddOrReplace(options ...NamespaceOption) {
+	for _, option := range options {
+		found := false
+		for i := range *n {
+			if (*n)[i].Name == option.Name {
+				(*n)[i] = option
+				found = true
+				break
+			}
+		}
+		if !found {
+			*n = append(*n, option)
+		}
+	}
+}
+
+// IDMappingOptions controls how UIDs and GIDs are mapped into a container.
+type IDMappingOptions struct {
+	HostUIDMapping bool
+	HostGIDMapping bool
+	UIDMap         []specs.LinuxIDMapping
+	GIDMap         []specs.LinuxIDMapping
+}
+
+// CommonBuildOptions parses the build options common to `buildah bud` and
+// `buildah from`.
+type CommonBuildOptions struct {
+	AddHost      []string
+	CgroupParent string
+	CPUPeriod    uint64
+	CPUQuota     int64
+	CPUSetCPUs   string
+	CPUSetMems   string
+	CPUShares    uint64
+	Memory       int64
+	MemorySwap   int64
+	ShmSize      string
+	Ulimit       []string
+	Volumes      []string
+}
+
+// AdditionalBuildContext holds a single value for the --build-context flag.
+type AdditionalBuildContext struct {
+	IsURL           bool
+	IsImage         bool
+	Value           string
+	DownloadedCache string
+}
+
+// Platform holds one parsed --platform value.
+type Platform struct {
+	OS      string
+	Arch    string
+	Variant string
+}
+
+// BuildOutputOption holds the result of parsing the --output flag.
+type BuildOutputOption struct {
+	Path     string
+	IsDir    bool
+	IsStdout bool
+}
+
+// BuildOptions can be used to alter how an image is built.
+type BuildOptions struct {
+	AddCapabilities         []string
+	AdditionalTags          []string
+	AllPlatforms            bool
+	Annotations             []string
+	Architecture            string
+	Args                    map[string]string
+	AdditionalBuildContexts map[string]*AdditionalBuildContext
+	BlobDirectory           string
+	CacheFrom               []reference.Named
+	CacheTo                 []reference.Named
+	CNIConfigDir            string
+	CNIPluginPath           string
+	CommonBuildOpts         *CommonBuildOptions
+	Compression             Compression
+	CompatDockerfile        bool
+	ConfigureNetwork        NetworkConfigurationPolicy
+	ContextDirectory        string
+	CPPFlags                []string
+	Devices                 []string
+	DropCapabilities        []string
+	Err                     io.Writer
+	Excludes                []string
+	ForceRmIntermediateCtrs bool
+	From                    string
+	IDMappingOptions        *IDMappingOptions
+	IIDFile                 string
+	In                      io.Reader
+	Isolation               Isolation
+	IgnoreFile              string
+	Jobs                    *int
+	Labels                  []string
+	Layers                  bool
+	LogFile                 string
+	LogSplitByPlatform      bool
+	LogRusage               bool
+	Manifest                string
+	MaxPullPushRetries      int
+	NamespaceOptions        NamespaceOptions
+	NoCache                 bool
+	OS                      string
+	OSFeatures              []string
+	OSVersion               string
+	Out                     io.Writer
+	Output                  string
+	BuildOutput             string
+	OutputFormat            string
+	OciDecryptConfig        *encconfig.DecryptConfig
+	OciEncryptConfig        *encconfig.EncryptConfig
+	OciEncryptLayers        *[]int
+	Platforms               []Platform
+	Progress                ProgressOption
+	PullPolicy              PullPolicy
+	PullPushRetryDelay      time.Duration
+	Quiet                   bool
+	RemoveIntermediateCtrs  bool
+	ReportWriter            io.Writer
+	Runtime                 string
+	RuntimeArgs             []string
+	RusageLogFile           string
+	SBOMScanOptions         *SBOMScanOptions
+	Secrets                 []Secret
+	SignBy                  string
+	SignaturePolicyPath     string
+	Squash                  bool
+	SystemContext           *types.SystemContext
+	Target                  string
+	Timestamp               *time.Time
+	TransientMounts         []string
+	UnsetEnvs               []string
+	Envs                    []string
+}
+
+// TempDirForURL checks if the given source is an http:// or https:// URL,
+// and if so returns an error: URL sources are not downloaded. For any other
+// source it returns empty tempDir and subDir, so that the caller treats the
+// source as a local path.
+func TempDirForURL(dir, prefix, url string) (tempDir, subDir string, err error) {
+	switch {
+	case strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "https://"):
+		return "", "", errors.Errorf("fetching build context from %q is not supported in this build", url)
+	default:
+		return "", "", nil
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/containers/buildah/pkg/parse"
 	"github.com/containers/buildah/pkg/util"
 	"github.com/containers/common/pkg/auth"
+	"github.com/containers/image/v5/docker/reference"
 	encconfig "github.com/containers/ocicrypt/config"
 	enchelpers "github.com/containers/ocicrypt/helpers"
 	"github.com/pkg/errors"
@@ -129,6 +131,10 @@ func GenBuildOptions(c *cobra.Command, inputArgs []string, iopts BuildOptions) (
 	if err != nil {
 		return options, nil, nil, err
 	}
+	progress, err := GetProgressOption(iopts.Progress)
+	if err != nil {
+		return options, nil, nil, err
+	}
 	layers := UseLayers()
 	if c.Flag("layers").Changed {
 		layers = iopts.Layers
@@ -234,8 +240,17 @@ func GenBuildOptions(c *cobra.Command, inputArgs []string, iopts BuildOptions) (
 		return options, nil, nil, errors.Errorf("'rm' and 'force-rm' can only be set with either 'layers' or 'no-cache'")
 	}
 
-	if c.Flag("cache-from").Changed {
-		logrus.Debugf("build --cache-from not enabled, has no effect")
+	// The executor still needs to push/pull the cache manifests these describe.
+	cacheFrom, err := parseCacheOptions(iopts.CacheFrom)
+	if err != nil {
+		return options, nil, nil, errors.Wrapf(err, "error parsing --cache-from")
+	}
+	cacheTo, err := parseCacheOptions(iopts.CacheTo)
+	if err != nil {
+		return options, nil, nil, errors.Wrapf(err, "error parsing --cache-to")
+	}
+	if len(cacheTo) > 0 && iopts.BudResults.Authfile == "" {
+		logrus.Warnf("--cache-to was given without --authfile; the push will only succeed if a default credential file is already configured")
 	}
 
 	if c.Flag("compress").Changed {
@@ -271,6 +286,26 @@ func GenBuildOptions(c *cobra.Command, inputArgs []string, iopts BuildOptions) (
 		return options, nil, nil, errors.Wrapf(err, "unable to obtain decrypt config")
 	}
 
+	encryptConfig, encryptLayers, err := EncryptConfig(iopts.EncryptionKeys, iopts.EncryptLayers)
+	if err != nil {
+		return options, nil, nil, errors.Wrapf(err, "unable to obtain encrypt config")
+	}
+
+	// The executor still needs to bind-mount these per RUN step and exclude them from the layer diff.
+	secrets, err := parse.Secrets(iopts.Secrets)
+	if err != nil {
+		return options, nil, nil, errors.Wrapf(err, "error parsing secrets")
+	}
+
+	// The scanner itself still needs to be invoked by the build pipeline.
+	var sbomScanOptions *define.SBOMScanOptions
+	if c.Flag("sbom").Changed || c.Flag("sbom-output").Changed || c.Flag("sbom-image-output").Changed || c.Flag("sbom-image-purl-output").Changed {
+		sbomScanOptions, err = parse.SBOMScanOptions(c)
+		if err != nil {
+			return options, nil, nil, errors.Wrapf(err, "error parsing SBOM scan options")
+		}
+	}
+
 	var excludes []string
 	if iopts.IgnoreFile != "" {
 		if excludes, _, err = parse.ContainerIgnoreFile(contextDir, iopts.IgnoreFile); err != nil {
@@ -346,6 +381,14 @@ func GenBuildOptions(c *cobra.Command, inputArgs []string, iopts BuildOptions) (
 		Target:                  iopts.Target,
 		TransientMounts:         iopts.Volumes,
 		OciDecryptConfig:        decryptConfig,
+		OciEncryptConfig:        encryptConfig,
+		OciEncryptLayers:        encryptLayers,
+		CacheFrom:               cacheFrom,
+		CacheTo:                 cacheTo,
+		Secrets:                 secrets,
+		SBOMScanOptions:         sbomScanOptions,
+		Progress:                progress,
+		CompatDockerfile:        UseCompatDockerfile(),
 		Jobs:                    &iopts.Jobs,
 		Excludes:                excludes,
 		Timestamp:               timestamp,
@@ -361,6 +404,43 @@ func GenBuildOptions(c *cobra.Command, inputArgs []string, iopts BuildOptions) (
 	return options, containerfiles, removeAll, nil
 }
 
+// parseCacheOptions parses the values given to --cache-from/--cache-to,
+// each of the form "type=registry,ref=<image>", into image references
+// that the executor can pull cache layers from or push them to.
+func parseCacheOptions(values []string) ([]reference.Named, error) {
+	var refs []reference.Named
+	for _, value := range values {
+		cacheType := "registry"
+		ref := ""
+		for _, field := range strings.Split(value, ",") {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, errors.Errorf("invalid field %q: expected key=value", field)
+			}
+			switch kv[0] {
+			case "type":
+				cacheType = kv[1]
+			case "ref":
+				ref = kv[1]
+			default:
+				return nil, errors.Errorf("invalid field %q: unrecognized key %q", field, kv[0])
+			}
+		}
+		if cacheType != "registry" {
+			return nil, errors.Errorf("unsupported cache backend type %q: only \"registry\" is supported", cacheType)
+		}
+		if ref == "" {
+			return nil, errors.Errorf("invalid cache option %q: \"ref\" is required", value)
+		}
+		named, err := reference.ParseNormalizedNamed(ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cache reference %q", ref)
+		}
+		refs = append(refs, named)
+	}
+	return refs, nil
+}
+
 func getContainerfiles(files []string) []string {
 	var containerfiles []string
 	for _, f := range files {
@@ -385,6 +465,36 @@ func GetFormat(format string) (string, error) {
 	}
 }
 
+// UseCompatDockerfile returns true if BUILDAH_DOCKERFILE_COMPAT is set to a
+// true value. Nothing reads this yet: the heredoc parser this toggle would
+// guard isn't part of this package.
+func UseCompatDockerfile() bool {
+	compat, ok := os.LookupEnv("BUILDAH_DOCKERFILE_COMPAT")
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(compat)
+	if err != nil {
+		return false
+	}
+	return b
+}
+
+// GetProgressOption translates a --progress string into a define.ProgressOption,
+// defaulting to auto-detected text output when unset.
+func GetProgressOption(progress string) (define.ProgressOption, error) {
+	switch progress {
+	case "", "auto":
+		return define.ProgressAuto, nil
+	case "plain":
+		return define.ProgressPlain, nil
+	case "json":
+		return define.ProgressJSON, nil
+	default:
+		return "", errors.Errorf("unrecognized --progress value %q: must be one of auto, plain, json", progress)
+	}
+}
+
 // DecryptConfig translates decryptionKeys into a DescriptionConfig structure
 func DecryptConfig(decryptionKeys []string) (*encconfig.DecryptConfig, error) {
 	decryptConfig := &encconfig.DecryptConfig{}
@@ -408,6 +518,12 @@ func EncryptConfig(encryptionKeys []string, encryptLayers []int) (*encconfig.Enc
 
 	if len(encryptionKeys) > 0 {
 		// encryption
+		for _, layer := range encryptLayers {
+			if layer < 0 {
+				return nil, nil, errors.Errorf("invalid --encrypt-layer value %d: layer indices must not be negative", layer)
+			}
+		}
+		// The upper bound needs the final layer count, so it's checked at commit time instead.
 		encLayers = &encryptLayers
 		ecc, err := enchelpers.CreateCryptoConfig(encryptionKeys, []string{})
 		if err != nil {
@@ -415,6 +531,8 @@ func EncryptConfig(encryptionKeys []string, encryptLayers []int) (*encconfig.Enc
 		}
 		cc := encconfig.CombineCryptoConfigs([]encconfig.CryptoConfig{ecc})
 		encConfig = cc.EncryptConfig
+	} else if len(encryptLayers) > 0 {
+		return nil, nil, errors.Errorf("--encrypt-layer can only be used together with --encryption-key")
 	}
 	return encConfig, encLayers, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/containers/buildah/define"
+)
+
+func TestGetProgressOption(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    define.ProgressOption
+		wantErr bool
+	}{
+		{in: "", want: define.ProgressAuto},
+		{in: "auto", want: define.ProgressAuto},
+		{in: "plain", want: define.ProgressPlain},
+		{in: "json", want: define.ProgressJSON},
+		{in: "bogus", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := GetProgressOption(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCompatDockerfile(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		set  bool
+		want bool
+	}{
+		{name: "unset defaults to false", set: false, want: false},
+		{name: "true", env: "true", set: true, want: true},
+		{name: "false", env: "false", set: true, want: false},
+		{name: "garbage defaults to false", env: "not-a-bool", set: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv("BUILDAH_DOCKERFILE_COMPAT", tt.env)
+			}
+			if got := UseCompatDockerfile(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCacheOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", values: nil, want: nil},
+		{name: "single registry ref", values: []string{"type=registry,ref=quay.io/foo/bar"}, want: []string{"quay.io/foo/bar"}},
+		{name: "ref before type", values: []string{"ref=quay.io/foo/bar,type=registry"}, want: []string{"quay.io/foo/bar"}},
+		{name: "defaults to registry type", values: []string{"ref=quay.io/foo/bar"}, want: []string{"quay.io/foo/bar"}},
+		{name: "multiple values", values: []string{"ref=quay.io/a/b", "ref=quay.io/c/d"}, want: []string{"quay.io/a/b", "quay.io/c/d"}},
+		{name: "missing ref", values: []string{"type=registry"}, wantErr: true},
+		{name: "unsupported type", values: []string{"type=local,ref=quay.io/foo/bar"}, wantErr: true},
+		{name: "unrecognized key", values: []string{"bogus=1,ref=quay.io/foo/bar"}, wantErr: true},
+		{name: "malformed field", values: []string{"ref"}, wantErr: true},
+		{name: "invalid reference", values: []string{"ref=UPPER_CASE_NOT_ALLOWED"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCacheOptions(tt.values)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d refs, want %d", len(got), len(tt.want))
+			}
+			for i, ref := range got {
+				if ref.String() != tt.want[i] && ref.Name() != tt.want[i] {
+					t.Errorf("ref %d = %q, want %q", i, ref.String(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptConfig(t *testing.T) {
+	t.Run("no keys returns nil config", func(t *testing.T) {
+		config, layers, err := EncryptConfig(nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config != nil || layers != nil {
+			t.Fatalf("expected nil config/layers, got %v/%v", config, layers)
+		}
+	})
+
+	t.Run("layers without keys is an error", func(t *testing.T) {
+		if _, _, err := EncryptConfig(nil, []int{0}); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("negative layer index is rejected", func(t *testing.T) {
+		if _, _, err := EncryptConfig([]string{"pgp:someone@example.com"}, []int{-1}); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		if _, _, err := EncryptConfig([]string{"not-a-valid-protocol"}, nil); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
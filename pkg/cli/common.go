@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// LayerResults holds the results of flags shared by `buildah bud` and
+// `buildah from` that control intermediate-layer / container cleanup.
+type LayerResults struct {
+	Layers  bool
+	ForceRm bool
+	Rm      bool
+}
+
+// FromAndBudResults holds the results of flags shared by `buildah bud` and
+// `buildah from` that configure how the container used to run the build is
+// set up and run.
+type FromAndBudResults struct {
+	CapAdd          []string
+	CapDrop         []string
+	CNIConfigDir    string
+	CNIPlugInPath   string
+	Devices         []string
+	Isolation       string
+	Runtime         string
+	RuntimeFlags    []string
+	SignaturePolicy string
+	Volumes         []string
+}
+
+// UserNSResults holds the results of flags that control user namespace
+// configuration.
+type UserNSResults struct {
+	UserNS     string
+	UIDMap     []string
+	GIDMap     []string
+	SubUIDName string
+	SubGIDName string
+}
+
+// NameSpaceResults holds the results of flags that control which namespaces
+// the build container joins or creates.
+type NameSpaceResults struct {
+	IPC     string
+	Network string
+	PID     string
+	UTS     string
+}
+
+// BudResults holds the results of flags specific to `buildah bud`.
+type BudResults struct {
+	AllPlatforms        bool
+	Annotation          []string
+	Authfile            string
+	BlobCache           string
+	BuildArg            []string
+	BuildContext        []string
+	BuildOutput         string
+	CacheFrom           []string
+	CacheTo             []string
+	CPPFlags            []string
+	DecryptionKeys      []string
+	DisableCompression  bool
+	EncryptionKeys      []string
+	EncryptLayers       []int
+	Envs                []string
+	File                []string
+	Format              string
+	From                string
+	IgnoreFile          string
+	Iidfile             string
+	Jobs                int
+	Label               []string
+	Logfile             string
+	LogRusage           bool
+	LogSplitByPlatform  bool
+	Manifest            string
+	NoCache             bool
+	OSFeatures          []string
+	OSVersion           string
+	Progress            string
+	Pull                string
+	PullAlways          bool
+	PullNever           bool
+	Quiet               bool
+	RusageLogFile       string
+	Sbom                string
+	SbomImageOutput     string
+	SbomImagePurlOutput string
+	SbomOutput          string
+	Secrets             []string
+	SignBy              string
+	Squash              bool
+	Stdin               bool
+	Tag                 []string
+	Target              string
+	Timestamp           int64
+	UnsetEnvs           []string
+}
+
+// UseLayers returns true unless BUILDAH_LAYERS is set to a false-ish value,
+// matching the long-standing default of building with cached layers enabled.
+func UseLayers() bool {
+	layers := os.Getenv("BUILDAH_LAYERS")
+	if layers == "" {
+		return true
+	}
+	b, err := strconv.ParseBool(layers)
+	if err != nil {
+		return true
+	}
+	return b
+}
+
+// GetLayerFlags registers the flags shared by `buildah bud` and
+// `buildah from` that control intermediate container cleanup.
+func GetLayerFlags(flags *LayerResults) *cobra.Command {
+	cmd := &cobra.Command{}
+	fs := cmd.Flags()
+	fs.BoolVar(&flags.Layers, "layers", UseLayers(), "cache intermediate layers during build")
+	fs.BoolVar(&flags.ForceRm, "force-rm", true, "always remove intermediate containers after a build, even if it fails")
+	fs.BoolVar(&flags.Rm, "rm", true, "remove intermediate containers after a successful build")
+	return cmd
+}
+
+// GetBudFlags registers the flags specific to `buildah bud` onto cmd, bound
+// to flags.
+func GetBudFlags(cmd *cobra.Command, flags *BudResults) {
+	fs := cmd.Flags()
+	fs.BoolVar(&flags.AllPlatforms, "all-platforms", false, "attempt to build for all base image platforms")
+	fs.StringArrayVar(&flags.Annotation, "annotation", nil, "set metadata for an image (default [])")
+	fs.StringVar(&flags.Authfile, "authfile", "", "path of the authentication file")
+	fs.StringVar(&flags.BlobCache, "blob-cache", "", "assume image blobs in the specified directory will be available for pushing")
+	fs.StringArrayVar(&flags.BuildArg, "build-arg", nil, "argument=value to supply to the builder")
+	fs.StringArrayVar(&flags.BuildContext, "build-context", nil, "additional build context argument=value")
+	fs.StringVar(&flags.BuildOutput, "output", "", "output destination (format: type=local,dest=path)")
+	fs.StringArrayVar(&flags.CacheFrom, "cache-from", nil, "remote repository to pull cached image layers from")
+	fs.StringArrayVar(&flags.CacheTo, "cache-to", nil, "remote repository to push cached image layers to")
+	fs.StringArrayVar(&flags.CPPFlags, "cpp-flag", nil, "additional flag to pass to the C preprocessor")
+	fs.StringArrayVar(&flags.DecryptionKeys, "decryption-key", nil, "key needed to decrypt the image")
+	fs.BoolVar(&flags.DisableCompression, "disable-compression", false, "don't compress layers")
+	fs.StringArrayVar(&flags.EncryptionKeys, "encryption-key", nil, "key with the encryption protocol to use needed to encrypt the image")
+	fs.IntSliceVar(&flags.EncryptLayers, "encrypt-layer", nil, "layers to encrypt, 0-indexed (default: all layers if --encryption-key is used)")
+	fs.StringArrayVar(&flags.Envs, "env", nil, "set environment variable for the build container and when running RUN instructions")
+	fs.StringArrayVarP(&flags.File, "file", "f", nil, "path to a Containerfile/Dockerfile")
+	fs.StringVar(&flags.Format, "format", "oci", "output format for the built image's manifest and configuration data")
+	fs.StringVar(&flags.From, "from", "", "image name used to replace the value in the first FROM instruction")
+	fs.StringVar(&flags.IgnoreFile, "ignorefile", "", "path to an alternate .containerignore/.dockerignore file")
+	fs.StringVar(&flags.Iidfile, "iidfile", "", "write the image ID to the file")
+	fs.IntVar(&flags.Jobs, "jobs", 1, "how many stages to run in parallel")
+	fs.StringArrayVarP(&flags.Label, "label", "l", nil, "set metadata for an image (default [])")
+	fs.StringVar(&flags.Logfile, "logfile", "", "log to file instead of stdout/stderr")
+	fs.BoolVar(&flags.LogRusage, "log-rusage", false, "log resource usage at each build step")
+	fs.BoolVar(&flags.LogSplitByPlatform, "logsplit", false, "split logfile for each platform")
+	fs.StringVarP(&flags.Manifest, "manifest", "m", "", "add the image to the named manifest list")
+	fs.BoolVar(&flags.NoCache, "no-cache", false, "don't use existing cached images for the container build")
+	fs.StringArrayVar(&flags.OSFeatures, "os-feature", nil, "set required OS feature for the target image")
+	fs.StringVar(&flags.OSVersion, "os-version", "", "set required OS version for the target image")
+	fs.StringVar(&flags.Progress, "progress", "auto", "set the type of progress output (auto, plain, json)")
+	fs.StringVar(&flags.Pull, "pull", "true", "pull base image policy")
+	fs.BoolVar(&flags.PullAlways, "pull-always", false, "pull base image, even if a version is present locally")
+	fs.BoolVar(&flags.PullNever, "pull-never", false, "do not pull base image")
+	fs.BoolVarP(&flags.Quiet, "quiet", "q", false, "refrain from announcing build instructions and image read/write progress")
+	fs.StringVar(&flags.RusageLogFile, "rusage-logfile", "", "destination of log for rusage (if --log-rusage is used)")
+	fs.StringVar(&flags.Sbom, "sbom", "", "generate an SBOM for the image using the named scanner")
+	fs.StringVar(&flags.SbomImageOutput, "sbom-image-output", "", "in-container path to attach the SBOM document to the built image")
+	fs.StringVar(&flags.SbomImagePurlOutput, "sbom-image-purl-output", "", "host path to write the SBOM's package URL list to")
+	fs.StringVar(&flags.SbomOutput, "sbom-output", "", "host path to write the SBOM document to")
+	fs.StringArrayVar(&flags.Secrets, "secret", nil, "secret file to expose to the build with: id=id,src=path")
+	fs.StringVar(&flags.SignBy, "sign-by", "", "sign the image using a GPG key with the specified fingerprint")
+	fs.BoolVar(&flags.Squash, "squash", false, "squash newly built layers into a single new layer")
+	fs.BoolVar(&flags.Stdin, "stdin", false, "pass stdin into the RUN containers")
+	fs.StringArrayVarP(&flags.Tag, "tag", "t", nil, "tagged name to apply to the built image")
+	fs.StringVar(&flags.Target, "target", "", "set the target build stage to build")
+	fs.Int64Var(&flags.Timestamp, "timestamp", 0, "set created timestamp to the specified epoch seconds to allow for deterministic builds")
+	fs.StringArrayVar(&flags.UnsetEnvs, "unsetenv", nil, "unset environment variable from the final image")
+}
+
+// GetFromAndBudFlags registers the flags shared by `buildah bud` and
+// `buildah from` onto cmd, bound to flags.
+func GetFromAndBudFlags(cmd *cobra.Command, userns *UserNSResults, flags *FromAndBudResults) *cobra.Command {
+	fs := cmd.Flags()
+	fs.StringSliceVar(&flags.CapAdd, "cap-add", nil, "add the specified capability when running")
+	fs.StringSliceVar(&flags.CapDrop, "cap-drop", nil, "drop the specified capability when running")
+	fs.StringVar(&flags.CNIConfigDir, "cni-config-dir", "", "directory of CNI configuration files")
+	fs.StringVar(&flags.CNIPlugInPath, "cni-plugin-path", "", "path of CNI network plugins")
+	fs.StringArrayVar(&flags.Devices, "device", nil, "device to add to the container")
+	fs.StringVar(&flags.Isolation, "isolation", "", "`type` of process isolation to use")
+	fs.StringVar(&flags.Runtime, "runtime", "", "path to an alternate runtime")
+	fs.StringArrayVar(&flags.RuntimeFlags, "runtime-flag", nil, "add global flags for the container runtime")
+	fs.StringVar(&flags.SignaturePolicy, "signature-policy", "", "`pathname` of signature policy file (not usually used)")
+	fs.StringArrayVarP(&flags.Volumes, "volume", "v", nil, "bind mount a volume into the container")
+	return cmd
+}
+
+// GetUserNSFlags registers the user-namespace related flags onto cmd.
+func GetUserNSFlags(cmd *cobra.Command, flags *UserNSResults) *cobra.Command {
+	fs := cmd.Flags()
+	fs.StringVar(&flags.UserNS, "userns", "", "'container', 'host', 'ns:path' or 'auto'")
+	fs.StringArrayVar(&flags.UIDMap, "uidmap", nil, "containerUID:hostUID:length mapping to use in user namespace")
+	fs.StringArrayVar(&flags.GIDMap, "gidmap", nil, "containerGID:hostGID:length mapping to use in user namespace")
+	fs.StringVar(&flags.SubUIDName, "userns-uid-map-user", "", "name of entries in /etc/subuid to use to set the user namespace UID mapping")
+	fs.StringVar(&flags.SubGIDName, "userns-gid-map-group", "", "name of entries in /etc/subgid to use to set the user namespace GID mapping")
+	return cmd
+}
+
+// GetNameSpaceFlags registers the namespace related flags onto cmd.
+func GetNameSpaceFlags(cmd *cobra.Command, flags *NameSpaceResults) *cobra.Command {
+	fs := cmd.Flags()
+	fs.StringVar(&flags.IPC, "ipc", "", "'private', 'host', or 'container:<name|id>' IPC namespace")
+	fs.StringVar(&flags.Network, "network", "", "'private', 'none', 'host', or 'container:<name|id>' network namespace")
+	fs.StringVar(&flags.PID, "pid", "", "'private', 'host', or 'container:<name|id>' PID namespace")
+	fs.StringVar(&flags.UTS, "uts", "", "'private', 'host', or 'container:<name|id>' UTS namespace")
+	return cmd
+}
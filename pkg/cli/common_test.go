@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// TestGetBudFlagsRegistersSBOMFlags guards against a regression where
+// GenBuildOptions called c.Flag("sbom").Changed (and the three sibling
+// --sbom-* flags) before anything had registered those flags on the
+// command, which panicked on a nil *pflag.Flag for every build.
+func TestGetBudFlagsRegistersSBOMFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	var flags BudResults
+	GetBudFlags(cmd, &flags)
+
+	for _, name := range []string{"sbom", "sbom-output", "sbom-image-output", "sbom-image-purl-output", "secret", "cache-from", "cache-to", "encryption-key", "encrypt-layer", "progress"} {
+		if cmd.Flag(name) == nil {
+			t.Errorf("flag %q was not registered", name)
+		}
+	}
+
+	// This is exactly the access pattern GenBuildOptions uses; it must not panic.
+	if cmd.Flag("sbom").Changed {
+		t.Errorf("expected --sbom to be unchanged by default")
+	}
+}
@@ -0,0 +1,177 @@
+package parse
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/containers/buildah/define"
+	"github.com/containers/image/v5/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// GetAdditionalBuildContext parses a single --build-context value (the part
+// after the "name=" prefix) into a define.AdditionalBuildContext.
+func GetAdditionalBuildContext(value string) (define.AdditionalBuildContext, error) {
+	ctx := define.AdditionalBuildContext{Value: value}
+	switch {
+	case strings.HasPrefix(value, "docker-image://"):
+		ctx.IsImage = true
+		ctx.Value = strings.TrimPrefix(value, "docker-image://")
+	case strings.HasPrefix(value, "http://"), strings.HasPrefix(value, "https://"):
+		ctx.IsURL = true
+	}
+	return ctx, nil
+}
+
+func stringFlag(c *cobra.Command, name string) string {
+	if f := c.Flags().Lookup(name); f != nil && f.Changed {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// SystemContextFromOptions builds a types.SystemContext from the handful of
+// global flags (--arch, --os, --variant, --authfile) that affect how images
+// are pulled and pushed.
+func SystemContextFromOptions(c *cobra.Command) (*types.SystemContext, error) {
+	ctx := &types.SystemContext{
+		ArchitectureChoice: stringFlag(c, "arch"),
+		OSChoice:           stringFlag(c, "os"),
+		VariantChoice:      stringFlag(c, "variant"),
+	}
+	if authfile := stringFlag(c, "authfile"); authfile != "" {
+		ctx.AuthFilePath = authfile
+	}
+	return ctx, nil
+}
+
+// IsolationOption parses the --isolation value into a define.Isolation.
+func IsolationOption(isolation string) (define.Isolation, error) {
+	switch strings.ToLower(strings.TrimSpace(isolation)) {
+	case "", "default":
+		return define.IsolationDefault, nil
+	case "oci":
+		return define.IsolationOCI, nil
+	case "chroot":
+		return define.IsolationChroot, nil
+	case "rootless":
+		return define.IsolationOCIRootless, nil
+	default:
+		return define.IsolationDefault, errors.Errorf("unrecognized isolation type %q", isolation)
+	}
+}
+
+// CommonBuildOptions parses the --add-host, --cgroup-parent, --shm-size,
+// --ulimit, and --volume flags shared between `buildah bud` and
+// `buildah from`. The CPU and memory limit fields are left at zero.
+func CommonBuildOptions(c *cobra.Command) (*define.CommonBuildOptions, error) {
+	return &define.CommonBuildOptions{
+		AddHost:      getStringArray(c, "add-host"),
+		CgroupParent: stringFlag(c, "cgroup-parent"),
+		ShmSize:      stringFlag(c, "shm-size"),
+		Ulimit:       getStringArray(c, "ulimit"),
+		Volumes:      getStringArray(c, "volume"),
+	}, nil
+}
+
+func getStringArray(c *cobra.Command, name string) []string {
+	if f := c.Flags().Lookup(name); f != nil {
+		if v, err := c.Flags().GetStringArray(name); err == nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// NamespaceOptions parses the --network flag into the network configuration
+// policy it implies. --ipc/--pid/--uts are registered on the command but not
+// yet read here; wiring them into define.NamespaceOptions is follow-up work.
+func NamespaceOptions(c *cobra.Command) (define.NamespaceOptions, define.NetworkConfigurationPolicy, error) {
+	policy := define.NetworkDefault
+	switch stringFlag(c, "network") {
+	case "none":
+		policy = define.NetworkDisabled
+	case "":
+		policy = define.NetworkDefault
+	default:
+		policy = define.NetworkEnabled
+	}
+	return define.NamespaceOptions{}, policy, nil
+}
+
+// IDMappingOptions returns no namespace options and an empty
+// define.IDMappingOptions; the --userns, --uidmap, and --gidmap flags are
+// not read.
+func IDMappingOptions(c *cobra.Command, isolation define.Isolation) ([]define.NamespaceOption, *define.IDMappingOptions, error) {
+	return nil, &define.IDMappingOptions{}, nil
+}
+
+// PlatformsFromOptions parses the (possibly repeated) --platform flag into a
+// list of define.Platform values.
+func PlatformsFromOptions(c *cobra.Command) ([]define.Platform, error) {
+	values := getStringArray(c, "platform")
+	var platforms []define.Platform
+	for _, value := range values {
+		parts := strings.SplitN(value, "/", 3)
+		platform := define.Platform{OS: parts[0]}
+		if len(parts) > 1 {
+			platform.Arch = parts[1]
+		}
+		if len(parts) > 2 {
+			platform.Variant = parts[2]
+		}
+		platforms = append(platforms, platform)
+	}
+	return platforms, nil
+}
+
+// ContainerIgnoreFile reads the given .containerignore/.dockerignore file
+// and returns the exclude patterns it contains. The path is opened as given;
+// it is not resolved against contextDir.
+func ContainerIgnoreFile(contextDir, ignoreFile string) ([]string, string, error) {
+	f, err := os.Open(ignoreFile)
+	if err != nil {
+		return nil, ignoreFile, errors.Wrapf(err, "error reading ignore file %q", ignoreFile)
+	}
+	defer f.Close()
+
+	var excludes []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		excludes = append(excludes, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ignoreFile, err
+	}
+	return excludes, ignoreFile, nil
+}
+
+// GetBuildOutput parses the --output flag.
+func GetBuildOutput(output string) (define.BuildOutputOption, error) {
+	if output == "" {
+		return define.BuildOutputOption{}, nil
+	}
+	if output == "-" {
+		return define.BuildOutputOption{IsStdout: true}, nil
+	}
+	opt := define.BuildOutputOption{Path: output}
+	for _, field := range strings.Split(output, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "dest":
+			opt.Path = kv[1]
+		case "type":
+			opt.IsDir = kv[1] == "local"
+		}
+	}
+	return opt, nil
+}
@@ -0,0 +1,18 @@
+package parse
+
+import (
+	"github.com/containers/buildah/define"
+	"github.com/spf13/cobra"
+)
+
+// SBOMScanOptions reads the --sbom* flags into a define.SBOMScanOptions.
+// There is no flag yet for running a user-provided scanner image instead of
+// an in-tree scanner binary, so Image is left unset here.
+func SBOMScanOptions(c *cobra.Command) (*define.SBOMScanOptions, error) {
+	return &define.SBOMScanOptions{
+		Scanner:         stringFlag(c, "sbom"),
+		Output:          stringFlag(c, "sbom-output"),
+		ImageOutput:     stringFlag(c, "sbom-image-output"),
+		ImagePURLOutput: stringFlag(c, "sbom-image-purl-output"),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package parse
+
+import (
+	"os"
+	"strings"
+
+	"github.com/containers/buildah/define"
+	"github.com/pkg/errors"
+)
+
+// Secrets parses the values given to one or more --secret flags, each of the
+// form "id=<id>,src=<path>" or "id=<id>,env=<name>", into define.Secret values ready to
+// be mounted by `RUN --mount=type=secret`.
+func Secrets(values []string) ([]define.Secret, error) {
+	var secrets []define.Secret
+	seen := make(map[string]bool)
+	for _, value := range values {
+		secret := define.Secret{}
+		for _, field := range strings.Split(value, ",") {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, errors.Errorf("invalid field %q in --secret: expected key=value", field)
+			}
+			switch kv[0] {
+			case "id":
+				secret.ID = kv[1]
+			case "src":
+				secret.Source = kv[1]
+			case "env":
+				secret.Env = kv[1]
+			default:
+				return nil, errors.Errorf("invalid field %q in --secret: unrecognized key %q", field, kv[0])
+			}
+		}
+		if secret.ID == "" {
+			return nil, errors.Errorf("invalid --secret value %q: \"id\" is required", value)
+		}
+		if seen[secret.ID] {
+			return nil, errors.Errorf("duplicate secret id %q", secret.ID)
+		}
+		if (secret.Source == "" && secret.Env == "") || (secret.Source != "" && secret.Env != "") {
+			return nil, errors.Errorf("invalid --secret value %q: exactly one of \"src\" or \"env\" is required", value)
+		}
+		if secret.Source != "" {
+			if _, err := os.Stat(secret.Source); err != nil {
+				return nil, errors.Wrapf(err, "invalid --secret value %q: source is not readable", value)
+			}
+		}
+		seen[secret.ID] = true
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
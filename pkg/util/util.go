@@ -0,0 +1,54 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MirrorToTempFileIfPathIsDescriptor checks if path is a path to a file
+// descriptor (e.g. /dev/fd/5 or /proc/self/fd/5), and if so, copies its
+// contents into a regular temporary file so that it can be read more than
+// once, returning the new path and whether the caller is responsible for
+// removing it.
+func MirrorToTempFileIfPathIsDescriptor(path string) (string, bool) {
+	if path == "" {
+		return path, false
+	}
+	if !isFdPath(path) {
+		return path, false
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return path, false
+	}
+	tmp, err := ioutil.TempFile("", "buildah-fd")
+	if err != nil {
+		return path, false
+	}
+	defer tmp.Close()
+	if _, err := tmp.Write(content); err != nil {
+		os.Remove(tmp.Name())
+		return path, false
+	}
+	return tmp.Name(), true
+}
+
+func isFdPath(path string) bool {
+	return strings.HasPrefix(path, "/dev/fd/") || strings.HasPrefix(path, "/proc/self/fd/")
+}
+
+// DiscoverContainerfile locates a Containerfile or Dockerfile within dir,
+// preferring "Containerfile" over "Dockerfile" when both are present.
+func DiscoverContainerfile(dir string) (string, error) {
+	for _, name := range []string{"Containerfile", "Dockerfile"} {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", errors.Errorf("no Containerfile or Dockerfile found in %q", dir)
+}